@@ -0,0 +1,118 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// GenerateCRL issues a new Certificate Revocation List for ca, listing
+// every certificate currently revoked in store. The returned bytes are
+// the raw DER encoding of the CRL; use WriteCRLFiles to persist it
+// alongside a PEM copy.
+func GenerateCRL(ca *AuraeCA, store *RevocationStore, nextUpdate time.Time) ([]byte, error) {
+	signer, err := newFileSigner(ca)
+	if err != nil {
+		return nil, err
+	}
+
+	return GenerateCRLFromSigner(signer, store, nextUpdate)
+}
+
+// GenerateCRLFromSigner is GenerateCRL for a CA backed by an arbitrary
+// Signer, such as an HSM-backed one returned by NewPKCS11Signer.
+func GenerateCRLFromSigner(ca Signer, store *RevocationStore, nextUpdate time.Time) ([]byte, error) {
+	caCert := ca.Certificate()
+	if caCert == nil {
+		return nil, fmt.Errorf("CA signer has no associated certificate")
+	}
+
+	revoked := store.All()
+	entries := make([]x509.RevocationListEntry, 0, len(revoked))
+	for _, rec := range revoked {
+		serial, ok := new(big.Int).SetString(rec.SerialNumber, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid serial number in revocation store: %s", rec.SerialNumber)
+		}
+
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: rec.RevokedAt,
+			ReasonCode:     rec.Reason,
+		})
+	}
+
+	number, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CRL number: %w", err)
+	}
+
+	template := &x509.RevocationList{
+		Number:                    number,
+		ThisUpdate:                time.Now(),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: entries,
+	}
+
+	derBytes, err := x509.CreateRevocationList(rand.Reader, template, caCert, ca)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create revocation list: %w", err)
+	}
+
+	return derBytes, nil
+}
+
+// WriteCRLFiles writes derBytes, the DER encoding of a CRL returned by
+// GenerateCRL, to path as both crl.der and a PEM encoded crl.pem.
+func WriteCRLFiles(path string, derBytes []byte) error {
+	path = filepath.Clean(path)
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	derPath := filepath.Join(path, "crl.der")
+	if err := os.WriteFile(derPath, derBytes, 0o644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", derPath, err)
+	}
+
+	crlBuf, err := getCRLBuf(derBytes)
+	if err != nil {
+		return err
+	}
+
+	return writeStringToFile(filepath.Join(path, "crl.pem"), crlBuf.String())
+}
@@ -0,0 +1,76 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// TestGenerateCRL checks that a revoked serial shows up in the issued
+// CRL, and that an un-revoked serial does not.
+func TestGenerateCRL(t *testing.T) {
+	ca, err := CreateAuraeRootCA(t.TempDir(), "root.aurae.test", Options{KeyAlgorithm: ECDSAP256})
+	if err != nil {
+		t.Fatalf("CreateAuraeRootCA: %v", err)
+	}
+
+	store, err := NewRevocationStore("")
+	if err != nil {
+		t.Fatalf("NewRevocationStore: %v", err)
+	}
+
+	revokedSerial := big.NewInt(12345)
+	if err := store.Revoke(revokedSerial, int(ocsp.CessationOfOperation)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+
+	derBytes, err := GenerateCRL(ca, store, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateCRL: %v", err)
+	}
+
+	crl, err := x509.ParseRevocationList(derBytes)
+	if err != nil {
+		t.Fatalf("ParseRevocationList: %v", err)
+	}
+
+	if len(crl.RevokedCertificateEntries) != 1 {
+		t.Fatalf("got %d revoked entries, want 1", len(crl.RevokedCertificateEntries))
+	}
+	if crl.RevokedCertificateEntries[0].SerialNumber.Cmp(revokedSerial) != 0 {
+		t.Fatalf("revoked serial = %s, want %s", crl.RevokedCertificateEntries[0].SerialNumber, revokedSerial)
+	}
+}
@@ -0,0 +1,177 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Profile whitelists what a signed CSR is allowed to claim. SignCSR never
+// trusts KeyUsage, ExtKeyUsage, or validity from the requester — only
+// Subject and SANs come from the CSR itself.
+type Profile struct {
+	AllowedKeyUsages    x509.KeyUsage
+	AllowedExtKeyUsages []x509.ExtKeyUsage
+	Validity            time.Duration
+}
+
+// ServerProfile is the default profile for node server identities.
+var ServerProfile = Profile{
+	AllowedKeyUsages:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	AllowedExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	Validity:            24 * time.Hour * 90,
+}
+
+// ClientProfile is the default profile for node client identities.
+var ClientProfile = Profile{
+	AllowedKeyUsages:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	AllowedExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	Validity:            24 * time.Hour * 90,
+}
+
+// CreateCSR builds a PEM encoded PKCS#10 certificate signing request for
+// the given subject and SANs, signed by key. The caller keeps key to
+// itself and only ever hands the CA the resulting CSR.
+func CreateCSR(subject pkix.Name, sans SANs, key crypto.Signer) ([]byte, error) {
+	template := &x509.CertificateRequest{
+		Subject:     subject,
+		DNSNames:    sans.DNSNames,
+		IPAddresses: sans.IPAddresses,
+		URIs:        sans.URIs,
+	}
+
+	derBytes, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate request: %w", err)
+	}
+
+	var csrBytes []byte
+	csrBuf := bytes.NewBuffer(csrBytes)
+	err = pem.Encode(csrBuf, &pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: derBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write certificate request buffer: %w", err)
+	}
+
+	return csrBuf.Bytes(), nil
+}
+
+// SignCSR validates csrPEM and signs it against ca, producing a leaf
+// certificate constrained by profile. The Subject and SANs of the issued
+// certificate are taken from the CSR; KeyUsage, ExtKeyUsage, and validity
+// always come from profile, never from the requester. The returned
+// AuraeCert carries only the certificate: its Key is empty, since the
+// private key stays with the requester.
+func SignCSR(ca *AuraeCA, csrPEM []byte, profile Profile) (*AuraeCert, error) {
+	signer, err := newFileSigner(ca)
+	if err != nil {
+		return &AuraeCert{}, err
+	}
+
+	return SignCSRFromSigner(signer, csrPEM, profile, WithRevocationEndpoints(ca.CRLURL, ca.OCSPURL))
+}
+
+// SignCSRFromSigner is SignCSR for a CA backed by an arbitrary Signer,
+// such as an HSM-backed one returned by NewPKCS11Signer. opts are applied
+// to the certificate template after the profile, before signing.
+func SignCSRFromSigner(ca Signer, csrPEM []byte, profile Profile, opts ...CAOption) (*AuraeCert, error) {
+	caCert := ca.Certificate()
+	if caCert == nil {
+		return &AuraeCert{}, fmt.Errorf("CA signer has no associated certificate")
+	}
+
+	block, _ := pem.Decode(csrPEM)
+	if block == nil {
+		return &AuraeCert{}, fmt.Errorf("failed to decode certificate request PEM")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return &AuraeCert{}, fmt.Errorf("failed to parse certificate request: %w", err)
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return &AuraeCert{}, fmt.Errorf("certificate request has an invalid signature: %w", err)
+	}
+
+	template := &x509.Certificate{
+		Subject:               csr.Subject,
+		DNSNames:              csr.DNSNames,
+		IPAddresses:           csr.IPAddresses,
+		URIs:                  csr.URIs,
+		KeyUsage:              profile.AllowedKeyUsages,
+		ExtKeyUsage:           profile.AllowedExtKeyUsages,
+		BasicConstraintsValid: true,
+	}
+	template.NotBefore = time.Now()
+	template.NotAfter = template.NotBefore.Add(profile.Validity)
+	template.AuthorityKeyId = caCert.SubjectKeyId
+
+	for _, opt := range opts {
+		opt(template)
+	}
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	template.SerialNumber, err = rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return &AuraeCert{}, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	pubBytes, err := x509.MarshalPKIXPublicKey(csr.PublicKey)
+	if err != nil {
+		return &AuraeCert{}, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	pubHash := sha1.Sum(pubBytes)
+	template.SubjectKeyId = pubHash[:]
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, csr.PublicKey, ca)
+	if err != nil {
+		return &AuraeCert{}, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	certBuf, err := getCertBuf(derBytes)
+	if err != nil {
+		return &AuraeCert{}, err
+	}
+
+	return &AuraeCert{Certificate: certBuf.String()}, nil
+}
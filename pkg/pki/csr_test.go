@@ -0,0 +1,86 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto/x509/pkix"
+	"testing"
+)
+
+// TestSignCSR checks that a CSR signed against a Profile produces a leaf
+// certificate whose Subject/SANs come from the CSR but whose KeyUsage,
+// ExtKeyUsage, and validity are pinned to the profile rather than to
+// anything the requester asked for.
+func TestSignCSR(t *testing.T) {
+	ca, err := CreateAuraeRootCA(t.TempDir(), "root.aurae.test", Options{KeyAlgorithm: ECDSAP256})
+	if err != nil {
+		t.Fatalf("CreateAuraeRootCA: %v", err)
+	}
+
+	key, err := generateKey(ECDSAP256)
+	if err != nil {
+		t.Fatalf("generateKey: %v", err)
+	}
+
+	subject := pkix.Name{CommonName: "workload.aurae.test"}
+	sans := SANs{DNSNames: []string{"workload.aurae.test"}}
+
+	csrPEM, err := CreateCSR(subject, sans, key)
+	if err != nil {
+		t.Fatalf("CreateCSR: %v", err)
+	}
+
+	leaf, err := SignCSR(ca, csrPEM, ServerProfile)
+	if err != nil {
+		t.Fatalf("SignCSR: %v", err)
+	}
+
+	cert, err := parseCertificate(leaf.Certificate)
+	if err != nil {
+		t.Fatalf("parseCertificate: %v", err)
+	}
+
+	if cert.Subject.CommonName != subject.CommonName {
+		t.Fatalf("subject CommonName = %q, want %q", cert.Subject.CommonName, subject.CommonName)
+	}
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "workload.aurae.test" {
+		t.Fatalf("DNSNames = %v, want [workload.aurae.test]", cert.DNSNames)
+	}
+	if cert.KeyUsage != ServerProfile.AllowedKeyUsages {
+		t.Fatalf("KeyUsage = %v, want %v", cert.KeyUsage, ServerProfile.AllowedKeyUsages)
+	}
+	if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != ServerProfile.AllowedExtKeyUsages[0] {
+		t.Fatalf("ExtKeyUsage = %v, want %v", cert.ExtKeyUsage, ServerProfile.AllowedExtKeyUsages)
+	}
+	if validity := cert.NotAfter.Sub(cert.NotBefore); validity != ServerProfile.Validity {
+		t.Fatalf("validity = %v, want %v", validity, ServerProfile.Validity)
+	}
+}
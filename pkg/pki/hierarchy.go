@@ -0,0 +1,323 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// expiryThreshold is how close to a certificate's NotAfter EnsureHierarchy
+// will tolerate before refusing to reuse it.
+const expiryThreshold = 30 * 24 * time.Hour
+
+// Duration is a time.Duration that marshals as a human-readable string
+// (e.g. "8760h") in both JSON and YAML, since encoding/json otherwise
+// only accepts a raw int64 nanosecond count for time.Duration while
+// yaml.v3 already accepts strings like this for free.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to unmarshal duration: %w", err)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration %q: %w", s, err)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return fmt.Errorf("failed to unmarshal duration: %w", err)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration %q: %w", s, err)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+// FileStorage stores a CA's certificate and key as PEM files on disk,
+// via the same ca.crt/ca.key layout as CreateAuraeRootCA.
+type FileStorage struct {
+	Path string `json:"path" yaml:"path"`
+}
+
+// NodeStorage selects where a HierarchyNode's key material lives. Exactly
+// one of File or PKCS11 should be set.
+type NodeStorage struct {
+	File   *FileStorage  `json:"file,omitempty" yaml:"file,omitempty"`
+	PKCS11 *PKCS11Config `json:"pkcs11,omitempty" yaml:"pkcs11,omitempty"`
+}
+
+// HierarchyNode declares one CA in a hierarchy: either a root (when it
+// has no parent) or an intermediate signed by its parent.
+type HierarchyNode struct {
+	CommonName      string          `json:"commonName" yaml:"commonName"`
+	KeyAlgorithm    KeyAlgorithm    `json:"keyAlgorithm" yaml:"keyAlgorithm"`
+	Validity        Duration        `json:"validity" yaml:"validity"`
+	PathLen         int             `json:"pathLen" yaml:"pathLen"`
+	AllowedProfiles []string        `json:"allowedProfiles,omitempty" yaml:"allowedProfiles,omitempty"`
+	Storage         NodeStorage     `json:"storage" yaml:"storage"`
+	Children        []HierarchyNode `json:"children,omitempty" yaml:"children,omitempty"`
+
+	// CRLURL and OCSPURL, if set, are stamped onto this node's AuraeCA so
+	// every leaf certificate it issues carries them; see AuraeCA.CRLURL.
+	CRLURL  string `json:"crlUrl,omitempty" yaml:"crlUrl,omitempty"`
+	OCSPURL string `json:"ocspUrl,omitempty" yaml:"ocspUrl,omitempty"`
+}
+
+// HierarchyConfig is the declarative, GitOps-friendly description of an
+// entire CA hierarchy, rooted at one or more self-signed roots.
+type HierarchyConfig struct {
+	Roots []HierarchyNode `json:"roots" yaml:"roots"`
+}
+
+// Hierarchy is a HierarchyConfig together with the CAs that have been
+// materialized for it, keyed by CommonName.
+type Hierarchy struct {
+	Config HierarchyConfig
+	CAs    map[string]*AuraeCA
+}
+
+// LoadHierarchy reads a HierarchyConfig from configPath. The format is
+// chosen from the file extension: .json for JSON, anything else (.yaml,
+// .yml) for YAML.
+func LoadHierarchy(configPath string) (*Hierarchy, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hierarchy config %s: %w", configPath, err)
+	}
+
+	var config HierarchyConfig
+	if strings.EqualFold(filepath.Ext(configPath), ".json") {
+		err = json.Unmarshal(data, &config)
+	} else {
+		err = yaml.Unmarshal(data, &config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hierarchy config %s: %w", configPath, err)
+	}
+
+	return &Hierarchy{Config: config, CAs: map[string]*AuraeCA{}}, nil
+}
+
+// AllowedProfiles returns the profile names permitted for the node with
+// the given CommonName, or nil if it isn't found in the hierarchy.
+func (h *Hierarchy) AllowedProfiles(commonName string) []string {
+	var find func(nodes []HierarchyNode) []string
+	find = func(nodes []HierarchyNode) []string {
+		for _, node := range nodes {
+			if node.CommonName == commonName {
+				return node.AllowedProfiles
+			}
+			if profiles := find(node.Children); profiles != nil {
+				return profiles
+			}
+		}
+		return nil
+	}
+
+	return find(h.Config.Roots)
+}
+
+// EnsureHierarchy idempotently materializes every CA described by the
+// hierarchy's config: roots first, then their children in topological
+// order. A CA whose files already exist on disk is reused as-is, after
+// validating that it still matches the config (its AuthorityKeyId chains
+// to its parent's SubjectKeyId, and it isn't within expiryThreshold of
+// NotAfter). Anything missing is generated fresh.
+func EnsureHierarchy(h *Hierarchy) error {
+	for i := range h.Config.Roots {
+		if err := ensureNode(h, nil, &h.Config.Roots[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureNode materializes node and recurses into its children, signing
+// each against the Signer this node was just ensured as. Tracking a
+// Signer rather than an *AuraeCA through the recursion lets a PKCS11-
+// backed parent sign its children without its private key ever being
+// reconstituted from (nonexistent) PEM.
+func ensureNode(h *Hierarchy, parent Signer, node *HierarchyNode) error {
+	ca, signer, err := loadOrCreateNode(parent, node)
+	if err != nil {
+		return fmt.Errorf("failed to ensure CA %q: %w", node.CommonName, err)
+	}
+	if closer, ok := signer.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	h.CAs[node.CommonName] = ca
+
+	for i := range node.Children {
+		if err := ensureNode(h, signer, &node.Children[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func loadOrCreateNode(parent Signer, node *HierarchyNode) (*AuraeCA, Signer, error) {
+	if node.Storage.File != nil {
+		if ca, err := loadExistingFileCA(node.Storage.File.Path); err == nil {
+			if err := validateNode(parent, ca); err != nil {
+				return nil, nil, err
+			}
+			ca.CRLURL = node.CRLURL
+			ca.OCSPURL = node.OCSPURL
+
+			signer, err := newFileSigner(ca)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to load signer for existing CA %q: %w", node.CommonName, err)
+			}
+			return ca, signer, nil
+		}
+	}
+
+	return createNode(parent, node)
+}
+
+func loadExistingFileCA(path string) (*AuraeCA, error) {
+	certPEM, err := os.ReadFile(filepath.Join(path, "ca.crt"))
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := os.ReadFile(filepath.Join(path, "ca.key"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuraeCA{Certificate: string(certPEM), Key: string(keyPEM)}, nil
+}
+
+func validateNode(parent Signer, ca *AuraeCA) error {
+	cert, err := parseCertificate(ca.Certificate)
+	if err != nil {
+		return fmt.Errorf("failed to parse existing certificate: %w", err)
+	}
+
+	if time.Until(cert.NotAfter) < expiryThreshold {
+		return fmt.Errorf("existing certificate %s expires at %s, within the renewal threshold", cert.Subject.CommonName, cert.NotAfter)
+	}
+
+	if parent != nil {
+		parentCert := parent.Certificate()
+		if parentCert == nil {
+			return fmt.Errorf("parent signer has no associated certificate")
+		}
+
+		if !bytes.Equal(cert.AuthorityKeyId, parentCert.SubjectKeyId) {
+			return fmt.Errorf("existing certificate %s does not chain to its configured parent", cert.Subject.CommonName)
+		}
+	}
+
+	return nil
+}
+
+// createNode materializes node's CA fresh, from whichever storage backend
+// it declares, and returns both the CA (for persistence/API consumers)
+// and the Signer it should be signed with going forward. Exactly one of
+// node.Storage.File or node.Storage.PKCS11 must be set.
+func createNode(parent Signer, node *HierarchyNode) (*AuraeCA, Signer, error) {
+	opts := []CAOption{WithMaxPathLen(node.PathLen)}
+	if node.Validity > 0 {
+		opts = append(opts, WithValidity(time.Duration(node.Validity)))
+	}
+
+	var ca *AuraeCA
+	var signer Signer
+	var err error
+
+	switch {
+	case node.Storage.PKCS11 != nil:
+		signer, err = NewPKCS11Signer(*node.Storage.PKCS11)
+		if err != nil {
+			return &AuraeCA{}, nil, fmt.Errorf("failed to open PKCS#11 signer for %q: %w", node.CommonName, err)
+		}
+
+		if parent == nil {
+			ca, err = CreateAuraeRootCAFromSigner("", node.CommonName, signer, opts...)
+		} else {
+			ca, err = CreateAuraeIntermediateCAFromSigners(parent, signer, "", node.CommonName, opts...)
+		}
+	case node.Storage.File != nil:
+		path := node.Storage.File.Path
+		if parent == nil {
+			ca, err = CreateAuraeRootCA(path, node.CommonName, Options{KeyAlgorithm: node.KeyAlgorithm}, opts...)
+		} else {
+			ca, err = CreateAuraeIntermediateCAFromSigner(parent, path, node.CommonName, node.KeyAlgorithm, opts...)
+		}
+		if err == nil {
+			signer, err = newFileSigner(ca)
+		}
+	default:
+		return &AuraeCA{}, nil, fmt.Errorf("hierarchy node %q must set either storage.file or storage.pkcs11", node.CommonName)
+	}
+	if err != nil {
+		return &AuraeCA{}, nil, err
+	}
+
+	ca.CRLURL = node.CRLURL
+	ca.OCSPURL = node.OCSPURL
+	return ca, signer, nil
+}
@@ -0,0 +1,104 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestEnsureHierarchy materializes a root plus one intermediate from a
+// declarative config, then runs EnsureHierarchy again against the same
+// config and storage paths to confirm it reuses the CAs it already
+// created rather than regenerating them.
+func TestEnsureHierarchy(t *testing.T) {
+	dir := t.TempDir()
+	rootPath := filepath.Join(dir, "root")
+	intermediatePath := filepath.Join(dir, "intermediate")
+
+	config := HierarchyConfig{
+		Roots: []HierarchyNode{
+			{
+				CommonName:   "root.aurae.test",
+				KeyAlgorithm: ECDSAP256,
+				Storage:      NodeStorage{File: &FileStorage{Path: rootPath}},
+				Children: []HierarchyNode{
+					{
+						CommonName:   "intermediate.aurae.test",
+						KeyAlgorithm: ECDSAP256,
+						PathLen:      0,
+						Storage:      NodeStorage{File: &FileStorage{Path: intermediatePath}},
+					},
+				},
+			},
+		},
+	}
+
+	configPath := filepath.Join(dir, "hierarchy.json")
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if err := os.WriteFile(configPath, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	h, err := LoadHierarchy(configPath)
+	if err != nil {
+		t.Fatalf("LoadHierarchy: %v", err)
+	}
+	if err := EnsureHierarchy(h); err != nil {
+		t.Fatalf("EnsureHierarchy (first run): %v", err)
+	}
+
+	root := h.CAs["root.aurae.test"]
+	intermediate := h.CAs["intermediate.aurae.test"]
+	if root == nil || intermediate == nil {
+		t.Fatalf("EnsureHierarchy did not populate both CAs: %+v", h.CAs)
+	}
+
+	h2, err := LoadHierarchy(configPath)
+	if err != nil {
+		t.Fatalf("LoadHierarchy (second): %v", err)
+	}
+	if err := EnsureHierarchy(h2); err != nil {
+		t.Fatalf("EnsureHierarchy (second run): %v", err)
+	}
+
+	if h2.CAs["root.aurae.test"].Certificate != root.Certificate {
+		t.Fatalf("re-running EnsureHierarchy regenerated the root CA instead of reusing it")
+	}
+	if h2.CAs["intermediate.aurae.test"].Certificate != intermediate.Certificate {
+		t.Fatalf("re-running EnsureHierarchy regenerated the intermediate CA instead of reusing it")
+	}
+}
@@ -0,0 +1,111 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// CreateAuraeRootCAFromSigner self-signs a root CA certificate for the
+// keypair held by signer. Unlike CreateAuraeRootCA, the private key never
+// leaves signer: the returned AuraeCA.Key is empty, since there is no key
+// material to export from, e.g., an HSM-backed Signer.
+func CreateAuraeRootCAFromSigner(path string, domainName string, signer Signer, opts ...CAOption) (*AuraeCA, error) {
+	template := x509.Certificate{}
+	template.Subject = pkix.Name{
+		Organization:       []string{"Aurae"},
+		OrganizationalUnit: []string{"Runtime"},
+		StreetAddress:      []string{"aurae"},
+		Locality:           []string{"aurae"},
+		Country:            []string{"IS"},
+		CommonName:         domainName,
+	}
+	template.NotBefore = time.Now()
+	template.NotAfter = template.NotBefore.Add(24 * time.Hour * 9999)
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.DNSNames = []string{domainName}
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return &AuraeCA{}, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	template.SerialNumber = serialNumber
+
+	pubBytes, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return &AuraeCA{}, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	pubHash := sha1.Sum(pubBytes)
+	template.SubjectKeyId = pubHash[:]
+	template.AuthorityKeyId = template.SubjectKeyId
+
+	for _, opt := range opts {
+		opt(&template)
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, signer.Public(), signer)
+	if err != nil {
+		return &AuraeCA{}, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	if setter, ok := signer.(interface{ SetCertificate(*x509.Certificate) }); ok {
+		cert, err := x509.ParseCertificate(derBytes)
+		if err != nil {
+			return &AuraeCA{}, fmt.Errorf("failed to parse self-signed certificate: %w", err)
+		}
+		setter.SetCertificate(cert)
+	}
+
+	certBuf, err := getCertBuf(derBytes)
+	if err != nil {
+		return &AuraeCA{}, err
+	}
+
+	ca := &AuraeCA{Certificate: certBuf.String()}
+
+	if path != "" {
+		err = createCAFiles(path, ca)
+		if err != nil {
+			return ca, err
+		}
+	}
+
+	return ca, nil
+}
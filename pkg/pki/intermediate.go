@@ -0,0 +1,175 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// CreateAuraeIntermediateCA generates a new intermediate CA signed by
+// parent. The resulting CA can itself sign further intermediates (up to
+// its MaxPathLen) or, more commonly in Aurae, issue the leaf certificates
+// used for mTLS between nodes.
+func CreateAuraeIntermediateCA(parent *AuraeCA, path string, commonName string, keyAlgorithm KeyAlgorithm, opts ...CAOption) (*AuraeCA, error) {
+	signer, err := newFileSigner(parent)
+	if err != nil {
+		return &AuraeCA{}, err
+	}
+
+	return CreateAuraeIntermediateCAFromSigner(signer, path, commonName, keyAlgorithm, opts...)
+}
+
+// CreateAuraeIntermediateCAFromSigner is CreateAuraeIntermediateCA for a
+// parent backed by an arbitrary Signer, such as an HSM-backed one
+// returned by NewPKCS11Signer. The intermediate's own key is generated
+// in-process; use CreateAuraeIntermediateCAFromSigners if it must live in
+// an HSM instead.
+func CreateAuraeIntermediateCAFromSigner(parent Signer, path string, commonName string, keyAlgorithm KeyAlgorithm, opts ...CAOption) (*AuraeCA, error) {
+	priv, err := generateKey(keyAlgorithm)
+	if err != nil {
+		return &AuraeCA{}, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	ca, _, err := createIntermediateCA(parent, commonName, priv.Public(), opts...)
+	if err != nil {
+		return ca, err
+	}
+
+	keyBuf, err := getKeyBuf(priv)
+	if err != nil {
+		return &AuraeCA{}, err
+	}
+	ca.Key = keyBuf.String()
+
+	if path != "" {
+		if err := createCAFiles(path, ca); err != nil {
+			return ca, err
+		}
+	}
+
+	return ca, nil
+}
+
+// CreateAuraeIntermediateCAFromSigners is CreateAuraeIntermediateCAFromSigner
+// for an intermediate whose own key must also never leave an HSM: child
+// holds the intermediate's keypair (as returned by NewPKCS11Signer). The
+// resulting certificate is associated back onto child via SetCertificate,
+// mirroring CreateAuraeRootCAFromSigner, and AuraeCA.Key is left empty
+// since there is no key material to export.
+func CreateAuraeIntermediateCAFromSigners(parent Signer, child Signer, path string, commonName string, opts ...CAOption) (*AuraeCA, error) {
+	ca, cert, err := createIntermediateCA(parent, commonName, child.Public(), opts...)
+	if err != nil {
+		return ca, err
+	}
+
+	if setter, ok := child.(interface{ SetCertificate(*x509.Certificate) }); ok {
+		setter.SetCertificate(cert)
+	}
+
+	if path != "" {
+		if err := createCAFiles(path, ca); err != nil {
+			return ca, err
+		}
+	}
+
+	return ca, nil
+}
+
+// createIntermediateCA builds and signs the intermediate's certificate
+// template against parent/pub, leaving key export and path persistence to
+// its callers since those differ between a file-backed and an HSM-backed
+// child.
+func createIntermediateCA(parent Signer, commonName string, pub crypto.PublicKey, opts ...CAOption) (*AuraeCA, *x509.Certificate, error) {
+	parentCert := parent.Certificate()
+	if parentCert == nil {
+		return &AuraeCA{}, nil, fmt.Errorf("parent signer has no associated certificate")
+	}
+
+	template := x509.Certificate{}
+	template.Subject = pkix.Name{
+		Organization:       []string{"Aurae"},
+		OrganizationalUnit: []string{"Runtime"},
+		StreetAddress:      []string{"aurae"},
+		Locality:           []string{"aurae"},
+		Country:            []string{"IS"},
+		CommonName:         commonName,
+	}
+	template.NotBefore = time.Now()
+	template.NotAfter = template.NotBefore.Add(24 * time.Hour * 1825)
+	template.IsCA = true
+	template.MaxPathLen = 0
+	template.MaxPathLenZero = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return &AuraeCA{}, nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	template.SerialNumber = serialNumber
+
+	pubBytes, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return &AuraeCA{}, nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	pubHash := sha1.Sum(pubBytes)
+	template.SubjectKeyId = pubHash[:]
+	template.AuthorityKeyId = parentCert.SubjectKeyId
+
+	for _, opt := range opts {
+		opt(&template)
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, parentCert, pub, parent)
+	if err != nil {
+		return &AuraeCA{}, nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(derBytes)
+	if err != nil {
+		return &AuraeCA{}, nil, fmt.Errorf("failed to parse signed certificate: %w", err)
+	}
+
+	certBuf, err := getCertBuf(derBytes)
+	if err != nil {
+		return &AuraeCA{}, nil, err
+	}
+
+	return &AuraeCA{Certificate: certBuf.String()}, cert, nil
+}
@@ -0,0 +1,71 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestCreateAuraeRootCA_KeyAlgorithms checks that a root (and an
+// intermediate signed by it) can be created and round-tripped through
+// newFileSigner for every supported KeyAlgorithm.
+func TestCreateAuraeRootCA_KeyAlgorithms(t *testing.T) {
+	algorithms := []KeyAlgorithm{RSA2048, RSA4096, ECDSAP256, ECDSAP384, Ed25519}
+
+	for _, alg := range algorithms {
+		root, err := CreateAuraeRootCA(t.TempDir(), "root.aurae.test", Options{KeyAlgorithm: alg})
+		if err != nil {
+			t.Fatalf("algorithm %d: CreateAuraeRootCA: %v", alg, err)
+		}
+
+		rootSigner, err := newFileSigner(root)
+		if err != nil {
+			t.Fatalf("algorithm %d: newFileSigner(root): %v", alg, err)
+		}
+
+		intermediate, err := CreateAuraeIntermediateCA(root, t.TempDir(), "intermediate.aurae.test", alg)
+		if err != nil {
+			t.Fatalf("algorithm %d: CreateAuraeIntermediateCA: %v", alg, err)
+		}
+
+		intermediateSigner, err := newFileSigner(intermediate)
+		if err != nil {
+			t.Fatalf("algorithm %d: newFileSigner(intermediate): %v", alg, err)
+		}
+
+		intermediateCert := intermediateSigner.Certificate()
+		rootCert := rootSigner.Certificate()
+		if !bytes.Equal(intermediateCert.AuthorityKeyId, rootCert.SubjectKeyId) {
+			t.Fatalf("algorithm %d: intermediate AuthorityKeyId does not chain to root SubjectKeyId", alg)
+		}
+	}
+}
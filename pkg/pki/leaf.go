@@ -0,0 +1,166 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AuraeCert is a PEM encoded leaf certificate and its private key, issued
+// by an AuraeCA for use by a single server or client identity.
+type AuraeCert struct {
+	Certificate string `json:"cert" yaml:"cert"`
+	Key         string `json:"key" yaml:"key"`
+}
+
+// IssueServerCertificate issues a leaf certificate authorized for
+// ServerAuth, suitable for the server side of an mTLS connection.
+func IssueServerCertificate(ca *AuraeCA, path string, commonName string, keyAlgorithm KeyAlgorithm, sans SANs, opts ...CAOption) (*AuraeCert, error) {
+	signer, err := newFileSigner(ca)
+	if err != nil {
+		return &AuraeCert{}, err
+	}
+
+	opts = append([]CAOption{WithSANs(sans), WithRevocationEndpoints(ca.CRLURL, ca.OCSPURL)}, opts...)
+	return issueLeafCertificateFromSigner(signer, path, commonName, keyAlgorithm, x509.ExtKeyUsageServerAuth, opts...)
+}
+
+// IssueClientCertificate issues a leaf certificate authorized for
+// ClientAuth, suitable for the client side of an mTLS connection.
+func IssueClientCertificate(ca *AuraeCA, path string, commonName string, keyAlgorithm KeyAlgorithm, opts ...CAOption) (*AuraeCert, error) {
+	signer, err := newFileSigner(ca)
+	if err != nil {
+		return &AuraeCert{}, err
+	}
+
+	opts = append([]CAOption{WithRevocationEndpoints(ca.CRLURL, ca.OCSPURL)}, opts...)
+	return issueLeafCertificateFromSigner(signer, path, commonName, keyAlgorithm, x509.ExtKeyUsageClientAuth, opts...)
+}
+
+func issueLeafCertificateFromSigner(ca Signer, path string, commonName string, keyAlgorithm KeyAlgorithm, extKeyUsage x509.ExtKeyUsage, opts ...CAOption) (*AuraeCert, error) {
+	caCert := ca.Certificate()
+	if caCert == nil {
+		return &AuraeCert{}, fmt.Errorf("CA signer has no associated certificate")
+	}
+
+	priv, err := generateKey(keyAlgorithm)
+	if err != nil {
+		return &AuraeCert{}, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	template := x509.Certificate{}
+	template.Subject = pkix.Name{
+		Organization:       []string{"Aurae"},
+		OrganizationalUnit: []string{"Runtime"},
+		CommonName:         commonName,
+	}
+	template.NotBefore = time.Now()
+	template.NotAfter = template.NotBefore.Add(24 * time.Hour * 90)
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = []x509.ExtKeyUsage{extKeyUsage}
+	template.BasicConstraintsValid = true
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	template.SerialNumber, err = rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return &AuraeCert{}, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	pubBytes, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		return &AuraeCert{}, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	pubHash := sha1.Sum(pubBytes)
+	template.SubjectKeyId = pubHash[:]
+	template.AuthorityKeyId = caCert.SubjectKeyId
+
+	for _, opt := range opts {
+		opt(&template)
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, caCert, priv.Public(), ca)
+	if err != nil {
+		return &AuraeCert{}, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	certBuf, err := getCertBuf(derBytes)
+	if err != nil {
+		return &AuraeCert{}, err
+	}
+	keyBuf, err := getKeyBuf(priv)
+	if err != nil {
+		return &AuraeCert{}, err
+	}
+
+	cert := &AuraeCert{
+		Certificate: certBuf.String(),
+		Key:         keyBuf.String(),
+	}
+
+	if path != "" {
+		err = createCertFiles(path, commonName, cert)
+		if err != nil {
+			return cert, err
+		}
+	}
+
+	return cert, nil
+}
+
+func createCertFiles(path string, commonName string, cert *AuraeCert) error {
+	path = filepath.Clean(path)
+	err := os.MkdirAll(path, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	crtPath := filepath.Join(path, commonName+".crt")
+	keyPath := filepath.Join(path, commonName+".key")
+
+	err = writeStringToFile(crtPath, cert.Certificate)
+	if err != nil {
+		return err
+	}
+
+	err = writeStringToFile(keyPath, cert.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
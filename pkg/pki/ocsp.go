@@ -0,0 +1,106 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// OCSPResponder answers OCSP requests for certificates issued by ca,
+// consulting store for revocation status.
+type OCSPResponder struct {
+	signer Signer
+	store  *RevocationStore
+}
+
+// NewOCSPResponder creates an OCSPResponder that signs its responses as
+// ca. In Aurae's hierarchy this is typically the issuing intermediate CA
+// itself, rather than a dedicated OCSP-signing delegate.
+func NewOCSPResponder(ca *AuraeCA, store *RevocationStore) (*OCSPResponder, error) {
+	signer, err := newFileSigner(ca)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOCSPResponderFromSigner(signer, store), nil
+}
+
+// NewOCSPResponderFromSigner is NewOCSPResponder for a CA backed by an
+// arbitrary Signer, such as an HSM-backed one returned by
+// NewPKCS11Signer.
+func NewOCSPResponderFromSigner(ca Signer, store *RevocationStore) *OCSPResponder {
+	return &OCSPResponder{signer: ca, store: store}
+}
+
+// Respond parses a DER encoded OCSP request and returns a signed,
+// DER encoded OCSP response reflecting the current state of the
+// responder's RevocationStore.
+func (r *OCSPResponder) Respond(req []byte) ([]byte, error) {
+	issuerCert := r.signer.Certificate()
+	if issuerCert == nil {
+		return nil, fmt.Errorf("OCSP responder signer has no associated certificate")
+	}
+
+	ocspReq, err := ocsp.ParseRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OCSP request: %w", err)
+	}
+
+	status := ocsp.Good
+	var revokedAt time.Time
+	var reason int
+
+	if rec, ok := r.store.Lookup(ocspReq.SerialNumber); ok {
+		status = ocsp.Revoked
+		revokedAt = rec.RevokedAt
+		reason = rec.Reason
+	}
+
+	template := ocsp.Response{
+		Status:           status,
+		SerialNumber:     ocspReq.SerialNumber,
+		ThisUpdate:       time.Now(),
+		NextUpdate:       time.Now().Add(24 * time.Hour),
+		RevokedAt:        revokedAt,
+		RevocationReason: reason,
+		Certificate:      issuerCert,
+	}
+
+	respBytes, err := ocsp.CreateResponse(issuerCert, issuerCert, template, r.signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCSP response: %w", err)
+	}
+
+	return respBytes, nil
+}
@@ -0,0 +1,103 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// TestOCSPResponderRespond checks that OCSPResponder.Respond reports a
+// leaf certificate as good before it is revoked, and as revoked after.
+func TestOCSPResponderRespond(t *testing.T) {
+	ca, err := CreateAuraeRootCA(t.TempDir(), "root.aurae.test", Options{KeyAlgorithm: ECDSAP256})
+	if err != nil {
+		t.Fatalf("CreateAuraeRootCA: %v", err)
+	}
+
+	leaf, err := IssueServerCertificate(ca, "", "leaf.aurae.test", ECDSAP256, SANs{DNSNames: []string{"leaf.aurae.test"}})
+	if err != nil {
+		t.Fatalf("IssueServerCertificate: %v", err)
+	}
+
+	issuerCert, err := parseCertificate(ca.Certificate)
+	if err != nil {
+		t.Fatalf("parseCertificate(ca): %v", err)
+	}
+	leafCert, err := parseCertificate(leaf.Certificate)
+	if err != nil {
+		t.Fatalf("parseCertificate(leaf): %v", err)
+	}
+
+	store, err := NewRevocationStore("")
+	if err != nil {
+		t.Fatalf("NewRevocationStore: %v", err)
+	}
+
+	responder, err := NewOCSPResponder(ca, store)
+	if err != nil {
+		t.Fatalf("NewOCSPResponder: %v", err)
+	}
+
+	reqBytes, err := ocsp.CreateRequest(leafCert, issuerCert, nil)
+	if err != nil {
+		t.Fatalf("ocsp.CreateRequest: %v", err)
+	}
+
+	respBytes, err := responder.Respond(reqBytes)
+	if err != nil {
+		t.Fatalf("Respond (before revoke): %v", err)
+	}
+	resp, err := ocsp.ParseResponse(respBytes, issuerCert)
+	if err != nil {
+		t.Fatalf("ocsp.ParseResponse (before revoke): %v", err)
+	}
+	if resp.Status != ocsp.Good {
+		t.Fatalf("status before revoke = %d, want ocsp.Good", resp.Status)
+	}
+
+	if err := store.Revoke(leafCert.SerialNumber, int(ocsp.CessationOfOperation)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+
+	respBytes, err = responder.Respond(reqBytes)
+	if err != nil {
+		t.Fatalf("Respond (after revoke): %v", err)
+	}
+	resp, err = ocsp.ParseResponse(respBytes, issuerCert)
+	if err != nil {
+		t.Fatalf("ocsp.ParseResponse (after revoke): %v", err)
+	}
+	if resp.Status != ocsp.Revoked {
+		t.Fatalf("status after revoke = %d, want ocsp.Revoked", resp.Status)
+	}
+}
@@ -0,0 +1,94 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto/x509"
+	"net"
+	"net/url"
+	"time"
+)
+
+// SANs holds the Subject Alternative Names that should be attached to a
+// leaf certificate. A certificate may carry any combination of DNS names,
+// IP addresses, and URIs (e.g. SPIFFE identities).
+type SANs struct {
+	DNSNames    []string
+	IPAddresses []net.IP
+	URIs        []*url.URL
+}
+
+// CAOption mutates a certificate template before it is signed. Options are
+// applied in order after the callee has populated the defaults, so later
+// options win when they touch the same field.
+type CAOption func(*x509.Certificate)
+
+// WithSANs attaches the given Subject Alternative Names to the certificate
+// template.
+func WithSANs(sans SANs) CAOption {
+	return func(template *x509.Certificate) {
+		template.DNSNames = sans.DNSNames
+		template.IPAddresses = sans.IPAddresses
+		template.URIs = sans.URIs
+	}
+}
+
+// WithValidity overrides the default validity period of the certificate
+// template.
+func WithValidity(d time.Duration) CAOption {
+	return func(template *x509.Certificate) {
+		template.NotBefore = time.Now()
+		template.NotAfter = template.NotBefore.Add(d)
+	}
+}
+
+// WithMaxPathLen constrains how many additional intermediate CAs may
+// appear below this one in the chain.
+func WithMaxPathLen(n int) CAOption {
+	return func(template *x509.Certificate) {
+		template.MaxPathLen = n
+		template.MaxPathLenZero = n == 0
+	}
+}
+
+// WithRevocationEndpoints attaches the CRL distribution point and OCSP
+// responder URLs that relying parties should use to check whether the
+// issued certificate has since been revoked. Either may be left empty.
+func WithRevocationEndpoints(crlURL, ocspURL string) CAOption {
+	return func(template *x509.Certificate) {
+		if crlURL != "" {
+			template.CRLDistributionPoints = []string{crlURL}
+		}
+		if ocspURL != "" {
+			template.OCSPServer = []string{ocspURL}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ThalesIgnite/crypto11"
+	"golang.org/x/term"
+)
+
+// PKCS11Config describes how to reach the token holding a CA's private
+// key. PIN is optional: if empty, it is read from the AURAE_PKCS11_PIN
+// environment variable, falling back to an interactive TTY prompt.
+type PKCS11Config struct {
+	ModulePath string
+	TokenLabel string
+	KeyLabel   string
+	PIN        string
+}
+
+// pkcs11Signer is a Signer whose private key never leaves the token. It
+// is found or generated by CKA_LABEL when NewPKCS11Signer opens the token.
+type pkcs11Signer struct {
+	ctx  *crypto11.Context
+	key  crypto11.Signer
+	cert *x509.Certificate
+}
+
+// NewPKCS11Signer opens the PKCS#11 token described by cfg and returns a
+// Signer backed by a keypair labeled cfg.KeyLabel, generating a new
+// RSA-2048 keypair on the token if one doesn't already exist.
+//
+// The returned Signer also implements io.Closer: callers that hold it for
+// any length of time (as opposed to a single signing operation) should
+// type-assert it and Close it when done, to release the underlying
+// PKCS#11 session instead of leaking it for the lifetime of the process.
+func NewPKCS11Signer(cfg PKCS11Config) (Signer, error) {
+	pin, err := resolvePIN(cfg.PIN)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := crypto11.Configure(&crypto11.Config{
+		Path:       cfg.ModulePath,
+		TokenLabel: cfg.TokenLabel,
+		Pin:        pin,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PKCS#11 token: %w", err)
+	}
+
+	label := []byte(cfg.KeyLabel)
+
+	key, err := ctx.FindKeyPair(label, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up PKCS#11 key %q: %w", cfg.KeyLabel, err)
+	}
+
+	if key == nil {
+		key, err = ctx.GenerateRSAKeyPairWithLabel(label, label, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate PKCS#11 key %q: %w", cfg.KeyLabel, err)
+		}
+	}
+
+	return &pkcs11Signer{ctx: ctx, key: key}, nil
+}
+
+func (s *pkcs11Signer) Public() crypto.PublicKey { return s.key.Public() }
+
+func (s *pkcs11Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.key.Sign(rand, digest, opts)
+}
+
+func (s *pkcs11Signer) Certificate() *x509.Certificate { return s.cert }
+
+// SetCertificate associates a CA certificate with this Signer once it has
+// been issued (self-signed for a root, or signed by a parent for an
+// intermediate).
+func (s *pkcs11Signer) SetCertificate(cert *x509.Certificate) { s.cert = cert }
+
+// Close releases the underlying PKCS#11 session. Callers that keep a
+// pkcs11Signer around (rather than using it for a single operation) must
+// call this, typically via a type assertion to io.Closer, once they are
+// done signing with it.
+func (s *pkcs11Signer) Close() error { return s.ctx.Close() }
+
+// resolvePIN resolves the PKCS#11 PIN from, in order, the explicit value,
+// the AURAE_PKCS11_PIN environment variable, or an interactive TTY
+// prompt.
+func resolvePIN(pin string) (string, error) {
+	if pin != "" {
+		return pin, nil
+	}
+
+	if envPIN := os.Getenv("AURAE_PKCS11_PIN"); envPIN != "" {
+		return envPIN, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Enter PKCS#11 token PIN: ")
+	pinBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PKCS#11 PIN: %w", err)
+	}
+
+	return string(pinBytes), nil
+}
@@ -32,6 +32,9 @@ package pki
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha1"
@@ -48,10 +51,17 @@ import (
 type AuraeCA struct {
 	Certificate string `json:"cert" yaml:"cert"`
 	Key         string `json:"key" yaml:"key"`
+
+	// CRLURL and OCSPURL, if set, are carried into the CRLDistributionPoints
+	// and OCSPServer extensions of every leaf certificate this CA issues
+	// (IssueServerCertificate, IssueClientCertificate, SignCSR), via
+	// WithRevocationEndpoints. Intermediate CAs it signs do not get them.
+	CRLURL  string `json:"crlUrl,omitempty" yaml:"crlUrl,omitempty"`
+	OCSPURL string `json:"ocspUrl,omitempty" yaml:"ocspUrl,omitempty"`
 }
 
-func CreateAuraeRootCA(path string, domainName string) (*AuraeCA, error) {
-	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+func CreateAuraeRootCA(path string, domainName string, options Options, opts ...CAOption) (*AuraeCA, error) {
+	priv, err := generateKey(options.KeyAlgorithm)
 	if err != nil {
 		return &AuraeCA{}, fmt.Errorf("failed to generate private key: %w", err)
 	}
@@ -70,6 +80,7 @@ func CreateAuraeRootCA(path string, domainName string) (*AuraeCA, error) {
 	template.IsCA = true
 	template.BasicConstraintsValid = true
 	template.DNSNames = []string{domainName}
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
 
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
 	template.SerialNumber, err = rand.Int(rand.Reader, serialNumberLimit)
@@ -88,13 +99,22 @@ func CreateAuraeRootCA(path string, domainName string) (*AuraeCA, error) {
 	// > If SubjectKeyId from template is empty and the template is a CA, SubjectKeyId
 	// > will be generated from the hash of the public key.
 	//
-	// We need a hash of the publickey, so hopefully this link is right
-	// https://stackoverflow.com/questions/52502511/how-to-generate-bytes-array-from-publickey#comment92269419_52502639
-	pubHash := sha1.Sum(priv.PublicKey.N.Bytes())
+	// The hash must be computed from the SPKI encoding rather than raw key
+	// material, since that's the only representation common to RSA, ECDSA,
+	// and Ed25519 public keys.
+	pubBytes, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		return &AuraeCA{}, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	pubHash := sha1.Sum(pubBytes)
 	template.SubjectKeyId = pubHash[:]
 	template.AuthorityKeyId = template.SubjectKeyId
 
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	for _, opt := range opts {
+		opt(&template)
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, priv.Public(), priv)
 	if err != nil {
 		return &AuraeCA{}, fmt.Errorf("failed to create certificate: %w", err)
 	}
@@ -174,14 +194,48 @@ func getCertBuf(derBytes []byte) (*bytes.Buffer, error) {
 	return certBuf, nil
 }
 
-func getKeyBuf(priv *rsa.PrivateKey) (*bytes.Buffer, error) {
-	var keyBytes []byte
-	keyBuf := bytes.NewBuffer(keyBytes)
-	err := pem.Encode(keyBuf, &pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(priv),
+func getCRLBuf(derBytes []byte) (*bytes.Buffer, error) {
+	var crlBytes []byte
+	crlBuf := bytes.NewBuffer(crlBytes)
+	err := pem.Encode(crlBuf, &pem.Block{
+		Type:  "X509 CRL",
+		Bytes: derBytes,
 	})
 	if err != nil {
+		return &bytes.Buffer{}, fmt.Errorf("failed to write CRL buffer: %w", err)
+	}
+
+	return crlBuf, nil
+}
+
+func getKeyBuf(priv crypto.Signer) (*bytes.Buffer, error) {
+	var keyBytes []byte
+	keyBuf := bytes.NewBuffer(keyBytes)
+
+	var block *pem.Block
+	switch key := priv.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return &bytes.Buffer{}, fmt.Errorf("failed to marshal EC private key: %w", err)
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	case ed25519.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return &bytes.Buffer{}, fmt.Errorf("failed to marshal Ed25519 private key: %w", err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	default:
+		return &bytes.Buffer{}, fmt.Errorf("unsupported private key type: %T", priv)
+	}
+
+	if err := pem.Encode(keyBuf, block); err != nil {
 		return &bytes.Buffer{}, fmt.Errorf("failed to write private key buffer: %w", err)
 	}
 
@@ -0,0 +1,139 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// RevokedCertificate records why and when a certificate, identified by
+// its serial number, was revoked. Reason uses the CRL reason codes from
+// RFC 5280 (golang.org/x/crypto/ocsp.CessationOfOperation and friends).
+type RevokedCertificate struct {
+	SerialNumber string    `json:"serial_number"`
+	RevokedAt    time.Time `json:"revoked_at"`
+	Reason       int       `json:"reason"`
+}
+
+// RevocationStore tracks revoked certificates, keyed by serial number.
+// When Path is set, every mutation is persisted to disk as JSON so the
+// store survives process restarts.
+type RevocationStore struct {
+	Path    string                         `json:"-"`
+	Entries map[string]RevokedCertificate `json:"entries"`
+
+	mu sync.Mutex
+}
+
+// NewRevocationStore creates a RevocationStore, loading existing entries
+// from path if it exists. An empty path keeps the store in-memory only.
+func NewRevocationStore(path string) (*RevocationStore, error) {
+	store := &RevocationStore{Path: path, Entries: map[string]RevokedCertificate{}}
+
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revocation store %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse revocation store %s: %w", path, err)
+	}
+
+	return store, nil
+}
+
+// Revoke marks serial as revoked for reason (an RFC 5280 CRL reason
+// code, such as ocsp.CessationOfOperation) as of now.
+func (s *RevocationStore) Revoke(serial *big.Int, reason int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Entries[serial.String()] = RevokedCertificate{
+		SerialNumber: serial.String(),
+		RevokedAt:    time.Now(),
+		Reason:       reason,
+	}
+
+	return s.save()
+}
+
+// Lookup reports whether serial has been revoked, and if so, the record
+// describing when and why.
+func (s *RevocationStore) Lookup(serial *big.Int) (RevokedCertificate, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.Entries[serial.String()]
+	return rec, ok
+}
+
+// All returns every revoked certificate currently tracked by the store.
+func (s *RevocationStore) All() []RevokedCertificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]RevokedCertificate, 0, len(s.Entries))
+	for _, rec := range s.Entries {
+		all = append(all, rec)
+	}
+
+	return all
+}
+
+func (s *RevocationStore) save() error {
+	if s.Path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal revocation store: %w", err)
+	}
+
+	if err := os.WriteFile(s.Path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write revocation store %s: %w", s.Path, err)
+	}
+
+	return nil
+}
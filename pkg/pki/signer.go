@@ -0,0 +1,83 @@
+/* -------------------------------------------------------------------------- *\
+ *             Apache 2.0 License Copyright © 2022 The Aurae Authors          *
+ *                                                                            *
+ *                +--------------------------------------------+              *
+ *                |   █████╗ ██╗   ██╗██████╗  █████╗ ███████╗ |              *
+ *                |  ██╔══██╗██║   ██║██╔══██╗██╔══██╗██╔════╝ |              *
+ *                |  ███████║██║   ██║██████╔╝███████║█████╗   |              *
+ *                |  ██╔══██║██║   ██║██╔══██╗██╔══██║██╔══╝   |              *
+ *                |  ██║  ██║╚██████╔╝██║  ██║██║  ██║███████╗ |              *
+ *                |  ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝╚═╝  ╚═╝╚══════╝ |              *
+ *                +--------------------------------------------+              *
+ *                                                                            *
+ *                         Distributed Systems Runtime                        *
+ *                                                                            *
+ * -------------------------------------------------------------------------- *
+ *                                                                            *
+ *   Licensed under the Apache License, Version 2.0 (the "License");          *
+ *   you may not use this file except in compliance with the License.         *
+ *   You may obtain a copy of the License at                                  *
+ *                                                                            *
+ *       http://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                            *
+ *   Unless required by applicable law or agreed to in writing, software      *
+ *   distributed under the License is distributed on an "AS IS" BASIS,        *
+ *   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ *   See the License for the specific language governing permissions and      *
+ *   limitations under the License.                                           *
+ *                                                                            *
+\* -------------------------------------------------------------------------- */
+
+package pki
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"io"
+)
+
+// Signer is anything capable of signing a certificate on behalf of a CA.
+// Every code path that signs a certificate (root/intermediate creation,
+// CSR signing, CRL and OCSP responses) goes through this interface, so
+// that a Signer backed by an HSM never has to hand its private key to
+// the caller.
+type Signer interface {
+	crypto.Signer
+
+	// Certificate returns the CA certificate associated with this Signer,
+	// or nil if the Signer has not been associated with one yet (for
+	// example, a freshly provisioned HSM key awaiting self-signing).
+	Certificate() *x509.Certificate
+}
+
+// fileSigner is the original in-memory/PEM-file backed Signer: the
+// private key lives in the process and is PEM-encoded to disk by
+// createCAFiles/createCertFiles.
+type fileSigner struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// newFileSigner loads a Signer from an AuraeCA's PEM certificate and key.
+func newFileSigner(ca *AuraeCA) (Signer, error) {
+	cert, err := parseCertificate(ca.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	key, err := parsePrivateKey(ca.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+
+	return &fileSigner{cert: cert, key: key}, nil
+}
+
+func (s *fileSigner) Public() crypto.PublicKey { return s.key.Public() }
+
+func (s *fileSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.key.Sign(rand, digest, opts)
+}
+
+func (s *fileSigner) Certificate() *x509.Certificate { return s.cert }